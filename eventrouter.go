@@ -2,40 +2,225 @@ package eventrouter
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 )
 
+//ErrSlowConsumer is surfaced on a subscription's error channel when the Disconnect policy
+//cancels it for failing to keep up with its bounded delivery queue.
+var ErrSlowConsumer = errors.New("eventrouter: subscriber disconnected: too slow")
+
 /*
 This function returns a function to send messages to a set of subscriptions, and a means of registering a subscription (and later cancelling iItemType)
-It has a cache (kept in RAM) for buffered operations.
+It has a cache (backed by a pluggable Store, e.g. NewMemoryStore or NewFileStore) for buffered operations.
 It uses generics, so this should be usable for items of any type
-Setting maxcachedepth to <1 leaves it unbounded.
+The store controls how many items are retained and whether they survive a restart; see Store.
 */
 
+//DropPolicy controls what a subscription does when its bounded delivery queue is full.
+type DropPolicy int
+
+const (
+	DropOldest DropPolicy = iota //discard the oldest queued item to make room for the new one
+	DropNewest                   //discard the incoming item, keeping the queue as-is
+	Block                        //block the calling Send until this subscriber's queue has room, without blocking the router's handling of anyone else
+	Disconnect                   //cancel the subscription and surface an error on its error channel
+)
+
+//defaultQueueSize is used when SubscribeOptions.QueueSize is <1.
+const defaultQueueSize = 64
+
+//SubscribeOptions configures a subscription's bounded delivery queue and its slow-consumer policy.
+type SubscribeOptions struct {
+	QueueSize int        //capacity of the per-subscriber delivery queue. <1 uses defaultQueueSize.
+	Policy    DropPolicy //what to do when the queue is full
+}
+
+//SubscriberStats reports how a single subscriber is keeping up.
+type SubscriberStats struct {
+	ID      int
+	Queued  int
+	Dropped uint64
+}
+
+//Envelope is what a Router actually hands to its Store: the item as submitted, plus the
+//sequence number and ingest time the router goroutine assigned it. Stores are generic over
+//Envelope[ItemType] rather than ItemType so that seq/time survive a restart alongside the item.
+type Envelope[ItemType any] struct {
+	Seq  uint64
+	Time time.Time
+	Item ItemType
+}
+
+//cursorKind selects how a Cursor picks its starting point among cached items.
+type cursorKind int
+
+const (
+	cursorOldest cursorKind = iota
+	cursorNewest
+	cursorSeq
+	cursorTime
+)
+
+//Cursor selects which cached items SubscribeFrom replays before it starts delivering new ones.
+//Build one with FromOldest, FromNewest, FromSeq, or FromTime.
+type Cursor struct {
+	kind cursorKind
+	seq  uint64
+	t    time.Time
+}
+
+//FromOldest replays every cached item, then all future submissions. This is what Tail does.
+func FromOldest() Cursor { return Cursor{kind: cursorOldest} }
+
+//FromNewest replays nothing cached; only future submissions are delivered. This is what Subscribe does.
+func FromNewest() Cursor { return Cursor{kind: cursorNewest} }
+
+//FromSeq replays cached items with a sequence number greater than seq, then all future
+//submissions. Pair with LastSeq to persist and resume a consumer's position across restarts.
+func FromSeq(seq uint64) Cursor { return Cursor{kind: cursorSeq, seq: seq} }
+
+//FromTime replays cached items ingested at or after t, then all future submissions.
+func FromTime(t time.Time) Cursor { return Cursor{kind: cursorTime, t: t} }
+
 type Router[ItemType any] struct {
-	Send      func(item ItemType)                                   //Submit an item to the router
-	Tail      func(ctx context.Context, onItem func(item ItemType)) //Get any cached items, and all future submissions. Equivalent to Items() and Subscribe()
-	Subscribe func(ctx context.Context, onItem func(item ItemType)) //Get all future submissions
-	Items     func() []ItemType                                     //Get the current list of items cached
-	Clear     func()                                                //Clear the item cache
-	Count     func() int                                            //Count the items in the cache
+	Send            func(ctx context.Context, item ItemType) error                                                                                            //Submit an item to the router. A non-nil error may mean ctx was cancelled, or that the item was fanned out live but the store failed to durably persist it
+	Tail            func(ctx context.Context, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error)                                         //Get any cached items, and all future submissions. Equivalent to Items() and Subscribe()
+	Subscribe       func(ctx context.Context, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error)                                         //Get all future submissions
+	TailFilter      func(ctx context.Context, predicate func(item ItemType) bool, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error)     //Like Tail, but onItem is only called for items matching predicate
+	SubscribeFilter func(ctx context.Context, predicate func(item ItemType) bool, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error)     //Like Subscribe, but onItem is only called for items matching predicate
+	SubscribeFrom   func(ctx context.Context, cursor Cursor, opts SubscribeOptions, onItem func(seq uint64, item ItemType)) (<-chan error, error)              //Replay cached items selected by cursor, tagged with their seq, then all future submissions
+	Items           func(ctx context.Context) ([]ItemType, error)                                                                                              //Get the current list of items cached
+	Clear           func(ctx context.Context) error                                                                                                            //Clear the item cache
+	Count           func(ctx context.Context) (int, error)                                                                                                     //Count the items in the cache
+	Stats           func(ctx context.Context) ([]SubscriberStats, error)                                                                                       //Per-subscriber queue depth and drop counts
+	LastSeq         func(ctx context.Context) (uint64, error)                                                                                                  //The sequence number of the most recently sent item, or 0 if none has been sent yet
 }
 
-func New[ItemType any](ctx context.Context, cacheDepth int) (router Router[ItemType]) {
+func New[ItemType any](ctx context.Context, store Store[Envelope[ItemType]]) (router Router[ItemType]) {
 
 	var ( //The API to the "server"
 		subscribeC = make(chan subItemsReq[ItemType])
 		sendC      = make(chan sendItemReq[ItemType])
 		cancelSubC = make(chan cancelSubReq[ItemType])
 		countC     = make(chan countItemsReq[ItemType])
+		getItemsC  = make(chan getItemsReq[ItemType])
 		clearC     = make(chan clearItemsReq[ItemType])
+		statsC     = make(chan statsReq[ItemType])
+		lastSeqC   = make(chan lastSeqReq[ItemType])
 	)
 
 	go func() { //All routing happens in this goroutine. Think of it as a webserver that responds, one at a time, to requests and dispatches responses
 		var ( //The guarded internal state. All mutation and observation happens through req/res pattern
-			cache         []ItemType
 			nextSubId     = 1000
-			subscriptions = make(map[int]chan updateMessage[ItemType])
+			subscriptions = make(map[int]*subscription[ItemType])
+			nextSeq       uint64 = 1 //0 is reserved to mean "nothing sent yet" for LastSeq
 		)
+		store.Range(func(e Envelope[ItemType]) bool {
+			nextSeq = e.Seq + 1
+			return true
+		})
+
+		//deliver applies sub's slow-consumer policy to get msg onto sub's queue without ever
+		//blocking the router goroutine itself, even when the policy is Block: a Block subscriber's
+		//message is handed to blockPending for blockPump to deliver in its own goroutine, so a
+		//stalled Block consumer backpressures only the Send that targeted it, never the router's
+		//handling of other subscribers, Count, Clear, or anything else.
+		//
+		//deliver reports whether sub is still connected afterward - callers that may deliver to the
+		//same sub more than once (the cache replay below) must stop once it returns false, since a
+		//disconnected sub's queue has been closed and sending to it again would panic - plus, for a
+		//Block subscriber, an ack channel that closes once msg has actually reached queue.
+		deliver := func(id int, sub *subscription[ItemType], msg updateMessage[ItemType]) (connected bool, ack <-chan struct{}) {
+			if sub.policy == Block {
+				a := make(chan struct{})
+				sub.blockMu.Lock()
+				sub.blockPending = append(sub.blockPending, blockDelivery[ItemType]{msg: msg, ack: a})
+				sub.blockMu.Unlock()
+				select {
+				case sub.blockWake <- struct{}{}:
+				default:
+				}
+				return true, a
+			}
+			select {
+			case sub.queue <- msg:
+				return true, nil
+			default:
+			}
+			switch sub.policy {
+			case DropOldest:
+				select {
+				case <-sub.queue:
+					sub.dropped++ //the evicted oldest item counts as a drop
+				default:
+				}
+				select {
+				case sub.queue <- msg:
+				default: //still no room after evicting; drop this one too
+					sub.dropped++
+				}
+			case DropNewest:
+				sub.dropped++
+			case Disconnect:
+				delete(subscriptions, id)
+				close(sub.queue)
+				select {
+				case sub.errC <- ErrSlowConsumer:
+				default:
+				}
+				close(sub.errC)
+				close(sub.done)
+				return false, nil
+			}
+			return true, nil
+		}
+
+		//blockPump owns the actual blocking send into a Block-policy sub's queue, draining
+		//blockPending strictly in order so concurrent Sends can never reorder its messages. It
+		//runs until sub.done is closed (the subscription was cancelled).
+		blockPump := func(sub *subscription[ItemType]) {
+			for {
+				sub.blockMu.Lock()
+				for len(sub.blockPending) == 0 {
+					sub.blockMu.Unlock()
+					select {
+					case <-sub.blockWake:
+					case <-sub.done:
+						return
+					}
+					sub.blockMu.Lock()
+				}
+				next := sub.blockPending[0]
+				sub.blockPending = sub.blockPending[1:]
+				sub.blockMu.Unlock()
+
+				select {
+				case sub.queue <- next.msg:
+					close(next.ack)
+				case <-sub.done:
+					return
+				}
+			}
+		}
+
+		//matches reports whether e should be replayed to a subscriber whose cursor is c.
+		matches := func(c Cursor, e Envelope[ItemType]) bool {
+			switch c.kind {
+			case cursorOldest:
+				return true
+			case cursorNewest:
+				return false
+			case cursorSeq:
+				return e.Seq > c.seq
+			case cursorTime:
+				return !e.Time.Before(c.t)
+			default:
+				return false
+			}
+		}
+
 		for {
 			select {
 			//Done
@@ -43,19 +228,41 @@ func New[ItemType any](ctx context.Context, cacheDepth int) (router Router[ItemT
 				return
 			//Cancel
 			case req := <-cancelSubC:
-				delete(subscriptions, req.id)
+				if sub, ok := subscriptions[req.id]; ok {
+					delete(subscriptions, req.id)
+					close(sub.done)
+					if sub.policy != Block { //a Block sub's blockPump may still be sending on queue; it exits on done instead
+						close(sub.queue)
+					}
+				}
 				req.resp <- cancelSubRes[ItemType]{}
 			//Subscribe
 			case req := <-subscribeC:
 				subId := nextSubId
-				subscriptions[subId] = make(chan updateMessage[ItemType])
+				queueSize := req.queueSize
+				if queueSize < 1 {
+					queueSize = defaultQueueSize
+				}
+				sub := &subscription[ItemType]{
+					queue:  make(chan updateMessage[ItemType], queueSize),
+					filter: req.filter,
+					policy: req.policy,
+					errC:   make(chan error, 1),
+					done:   make(chan struct{}),
+				}
+				if sub.policy == Block {
+					sub.blockWake = make(chan struct{}, 1)
+					go blockPump(sub)
+				}
+				subscriptions[subId] = sub
 				resp := subItemsRes[ItemType]{
-					id:      subId,
-					updateC: subscriptions[subId],
+					id:    subId,
+					queue: sub.queue,
+					errC:  sub.errC,
 					cancel: func() {
 						cancelReq := cancelSubReq[ItemType]{
 							id:   subId,
-							resp: make(chan cancelSubRes[ItemType]),
+							resp: make(chan cancelSubRes[ItemType], 1),
 						}
 
 						cancelSubC <- cancelReq
@@ -64,103 +271,284 @@ func New[ItemType any](ctx context.Context, cacheDepth int) (router Router[ItemT
 				}
 				req.resp <- resp
 
-				if req.tail {
-					for _, item := range cache {
-						resp.updateC <- updateMessage[ItemType]{item: item}
+				store.Range(func(e Envelope[ItemType]) bool {
+					if matches(req.cursor, e) && (sub.filter == nil || sub.filter(e.Item)) {
+						connected, _ := deliver(subId, sub, updateMessage[ItemType]{seq: e.Seq, item: e.Item})
+						if !connected {
+							return false //Disconnect policy fired and closed sub.queue; stop replaying to it
+						}
 					}
-				}
+					return true
+				})
 				nextSubId++
 			//Send
 			case req := <-sendC:
-				for _, sub := range subscriptions {
-					sub <- updateMessage[ItemType]{item: req.item}
-				}
-				if cacheDepth != 0 {
-					if overflow := len(cache) + 1 - cacheDepth; overflow > 0 {
-						cache = cache[overflow-1:]
+				env := Envelope[ItemType]{Seq: nextSeq, Time: time.Now(), Item: req.item}
+				nextSeq++
+				var acks []<-chan struct{}
+				for id, sub := range subscriptions {
+					if sub.filter == nil || sub.filter(req.item) {
+						if _, ack := deliver(id, sub, updateMessage[ItemType]{seq: env.Seq, item: req.item}); ack != nil {
+							acks = append(acks, ack)
+						}
 					}
 				}
-				cache = append(cache, req.item)
-				req.resp <- sendItemRes[ItemType]{}
+				//store.Append's error is surfaced back through Send rather than dropped: a failed
+				//append means the item was never made durable even though it was just fanned out
+				//live, so callers need to know it won't survive a restart.
+				appendErr := store.Append(env)
+				if len(acks) == 0 {
+					req.resp <- sendItemRes[ItemType]{err: appendErr}
+				} else {
+					//At least one Block subscriber is still owed this message. Wait for it off the
+					//router goroutine so Send backpressures only this call, not the router itself.
+					go func(acks []<-chan struct{}, resp chan sendItemRes[ItemType], appendErr error) {
+						for _, ack := range acks {
+							<-ack
+						}
+						resp <- sendItemRes[ItemType]{err: appendErr}
+					}(acks, req.resp, appendErr)
+				}
 			case req := <-countC:
-				req.resp <- countItemsRes[ItemType]{count: len(cache)}
+				req.resp <- countItemsRes[ItemType]{count: store.Len()}
+			//Items
+			case req := <-getItemsC:
+				items := make([]ItemType, 0, store.Len())
+				store.Range(func(e Envelope[ItemType]) bool {
+					items = append(items, e.Item)
+					return true
+				})
+				req.resp <- getItemsRes[ItemType]{items: items}
 			//Clear
 			case req := <-clearC:
-				cache = cache[:0]
+				store.Clear()
 				req.resp <- clearItemsRes[ItemType]{}
+			//Stats
+			case req := <-statsC:
+				stats := make([]SubscriberStats, 0, len(subscriptions))
+				for id, sub := range subscriptions {
+					queued := len(sub.queue)
+					if sub.policy == Block {
+						sub.blockMu.Lock()
+						queued += len(sub.blockPending)
+						sub.blockMu.Unlock()
+					}
+					stats = append(stats, SubscriberStats{ID: id, Queued: queued, Dropped: sub.dropped})
+				}
+				req.resp <- statsRes[ItemType]{stats: stats}
+			//LastSeq
+			case req := <-lastSeqC:
+				req.resp <- lastSeqRes[ItemType]{seq: nextSeq - 1}
 			}
 		}
 	}()
 
-	router.Send = func(item ItemType) {
-		req := sendItemReq[ItemType]{item: item, resp: make(chan sendItemRes[ItemType])}
-		sendC <- req
-		<-req.resp
+	router.Send = func(ctx context.Context, item ItemType) error {
+		req := sendItemReq[ItemType]{item: item, resp: make(chan sendItemRes[ItemType], 1)}
+		select {
+		case sendC <- req:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		select {
+		case res := <-req.resp:
+			return res.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 
-	//Call the server and subscribe to the router
-	sub := func(ctx context.Context, tail bool, onItem func(item ItemType)) {
+	//Call the server and subscribe to the router. A nil predicate matches every item. The
+	//initial handshake is bounded by ctx; once subscribed, the delivery goroutine below also
+	//watches ctx so a caller can always get out, even of a wedged router.
+	sub := func(ctx context.Context, cursor Cursor, predicate func(item ItemType) bool, opts SubscribeOptions, onItem func(seq uint64, item ItemType)) (<-chan error, error) {
 		sr := subItemsReq[ItemType]{
-			resp: make(chan subItemsRes[ItemType]),
-			tail: tail,
+			resp:      make(chan subItemsRes[ItemType], 1),
+			cursor:    cursor,
+			filter:    predicate,
+			queueSize: opts.QueueSize,
+			policy:    opts.Policy,
+		}
+		select {
+		case subscribeC <- sr: //issue a req
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var resp subItemsRes[ItemType]
+		select {
+		case resp = <-sr.resp:
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
-		subscribeC <- sr //issue a req
-		resp := <-sr.resp
 		go func() {
 			for {
 				select {
 				case <-ctx.Done():
 					resp.cancel()
 					return
-				case update := <-resp.updateC:
-					onItem(update.item)
+				case update, ok := <-resp.queue:
+					if !ok { //Disconnect policy closed our queue out from under us
+						return
+					}
+					onItem(update.seq, update.item)
 				}
 			}
 		}()
+		return resp.errC, nil
 	}
 	//Public API
-	router.Tail = func(ctx context.Context, onItem func(item ItemType)) { sub(ctx, true, onItem) }
-	router.Subscribe = func(ctx context.Context, onItem func(item ItemType)) { sub(ctx, false, onItem) }
-	router.Items = func() []ItemType {
-		req := getItemsReq[ItemType]{resp: make(chan getItemsRes[ItemType])}
-		res := <-req.resp
-		return res.items
+	router.Tail = func(ctx context.Context, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error) {
+		return sub(ctx, FromOldest(), nil, opts, func(_ uint64, item ItemType) { onItem(item) })
+	}
+	router.Subscribe = func(ctx context.Context, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error) {
+		return sub(ctx, FromNewest(), nil, opts, func(_ uint64, item ItemType) { onItem(item) })
 	}
-	router.Clear = func() {
-		req := clearItemsReq[ItemType]{resp: make(chan clearItemsRes[ItemType])}
-		<-req.resp
+	router.TailFilter = func(ctx context.Context, predicate func(item ItemType) bool, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error) {
+		return sub(ctx, FromOldest(), predicate, opts, func(_ uint64, item ItemType) { onItem(item) })
 	}
-	router.Count = func() int {
-		req := countItemsReq[ItemType]{resp: make(chan countItemsRes[ItemType])}
-		resp := <-req.resp
-		return resp.count
+	router.SubscribeFilter = func(ctx context.Context, predicate func(item ItemType) bool, opts SubscribeOptions, onItem func(item ItemType)) (<-chan error, error) {
+		return sub(ctx, FromNewest(), predicate, opts, func(_ uint64, item ItemType) { onItem(item) })
+	}
+	router.SubscribeFrom = func(ctx context.Context, cursor Cursor, opts SubscribeOptions, onItem func(seq uint64, item ItemType)) (<-chan error, error) {
+		return sub(ctx, cursor, nil, opts, onItem)
+	}
+	router.Items = func(ctx context.Context) ([]ItemType, error) {
+		req := getItemsReq[ItemType]{resp: make(chan getItemsRes[ItemType], 1)}
+		select {
+		case getItemsC <- req:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		select {
+		case res := <-req.resp:
+			return res.items, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	router.Clear = func(ctx context.Context) error {
+		req := clearItemsReq[ItemType]{resp: make(chan clearItemsRes[ItemType], 1)}
+		select {
+		case clearC <- req:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		select {
+		case <-req.resp:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	router.Count = func(ctx context.Context) (int, error) {
+		req := countItemsReq[ItemType]{resp: make(chan countItemsRes[ItemType], 1)}
+		select {
+		case countC <- req:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		select {
+		case resp := <-req.resp:
+			return resp.count, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	router.Stats = func(ctx context.Context) ([]SubscriberStats, error) {
+		req := statsReq[ItemType]{resp: make(chan statsRes[ItemType], 1)}
+		select {
+		case statsC <- req:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		select {
+		case resp := <-req.resp:
+			return resp.stats, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	router.LastSeq = func(ctx context.Context) (uint64, error) {
+		req := lastSeqReq[ItemType]{resp: make(chan lastSeqRes[ItemType], 1)}
+		select {
+		case lastSeqC <- req:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		select {
+		case resp := <-req.resp:
+			return resp.seq, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
 	}
 	return router
 }
 
 //the request and response datatypes used to interact with the core server
+//
+//Every resp channel below is allocated with capacity 1. Callers bound their wait on it with a
+//ctx.Done() branch (see router.Send etc. above), so if ctx is cancelled between handing off the
+//request and reading the response, the caller walks away from an unread resp. Since the server
+//goroutine is single-threaded and serial, an unbuffered resp would then block that goroutine
+//forever on the abandoned send, wedging every other caller too. The buffer of 1 lets the server
+//goroutine's send always succeed immediately, whether or not anyone is left to read it.
 
 type countItemsRes[ItemType any] struct{ count int }
 type countItemsReq[ItemType any] struct {
 	resp chan countItemsRes[ItemType]
 }
-type sendItemRes[ItemType any] struct{}
+type sendItemRes[ItemType any] struct{ err error }
 type sendItemReq[ItemType any] struct {
 	item ItemType
 	resp chan sendItemRes[ItemType]
 }
 
-type updateMessage[ItemType any] struct{ item ItemType }
+//subscription is the server-side bookkeeping for a single subscriber: its bounded delivery queue,
+//its (optional) filter, its slow-consumer policy, its drop counter, and its disconnect error channel.
+//Only the router goroutine ever touches dropped, so it needs no synchronization of its own.
+type subscription[ItemType any] struct {
+	queue   chan updateMessage[ItemType]
+	filter  func(ItemType) bool //nil means "interested in everything"
+	policy  DropPolicy
+	dropped uint64
+	errC    chan error
+	done    chan struct{} //closed when the subscription is cancelled or disconnected
+
+	//blockMu/blockPending/blockWake back a Policy: Block subscription's delivery. The router
+	//goroutine appends to blockPending and pings blockWake under blockMu without ever blocking
+	//itself; the blockPump goroutine started alongside the subscription drains blockPending into
+	//queue one message at a time in order, blocking only itself - never the router goroutine -
+	//when queue is full. Unused for every other policy.
+	blockMu      sync.Mutex
+	blockPending []blockDelivery[ItemType]
+	blockWake    chan struct{}
+}
+
+//blockDelivery is one router goroutine's pending delivery to a Block-policy subscriber: msg is
+//the item to deliver, and ack is closed by blockPump once it has actually been pushed onto queue,
+//so Send can wait for its own item to land without blocking on anyone else's.
+type blockDelivery[ItemType any] struct {
+	msg updateMessage[ItemType]
+	ack chan struct{}
+}
+
+type updateMessage[ItemType any] struct {
+	seq  uint64
+	item ItemType
+}
 type subItemsRes[ItemType any] struct {
-	id      int
-	tail    []ItemType
-	filter  func(ItemType) bool //return true for interested
-	updateC chan updateMessage[ItemType]
-	cancel  func()
+	id     int
+	queue  chan updateMessage[ItemType]
+	errC   chan error
+	cancel func()
 }
 type subItemsReq[ItemType any] struct {
-	tail bool
-	resp chan subItemsRes[ItemType]
+	cursor    Cursor
+	filter    func(ItemType) bool //nil means "interested in everything"
+	queueSize int
+	policy    DropPolicy
+	resp      chan subItemsRes[ItemType]
 }
 
 type getItemsRes[ItemType any] struct{ items []ItemType }
@@ -174,3 +562,86 @@ type cancelSubReq[ItemType any] struct {
 	id   int
 	resp chan cancelSubRes[ItemType]
 }
+
+type statsRes[ItemType any] struct{ stats []SubscriberStats }
+type statsReq[ItemType any] struct{ resp chan statsRes[ItemType] }
+
+type lastSeqRes[ItemType any] struct{ seq uint64 }
+type lastSeqReq[ItemType any] struct{ resp chan lastSeqRes[ItemType] }
+
+//Topic maintains a set of independent Routers, one per key, created lazily on first use.
+//This mirrors pub/sub systems (like micro's router service) where subscribers only see
+//events published under the topic they asked for, instead of every item flowing through
+//a single shared Router.
+type Topic[K comparable, V any] struct {
+	ctx      context.Context
+	newStore func() Store[Envelope[V]]
+
+	mu      sync.Mutex
+	routers map[K]Router[V]
+}
+
+//NewTopic creates a Topic whose per-key Routers are all built with the given context, each
+//backed by a fresh Store from newStore (e.g. func() Store[eventrouter.Envelope[V]] { return eventrouter.NewMemoryStore[eventrouter.Envelope[V]](100) }).
+func NewTopic[K comparable, V any](ctx context.Context, newStore func() Store[Envelope[V]]) *Topic[K, V] {
+	return &Topic[K, V]{
+		ctx:      ctx,
+		newStore: newStore,
+		routers:  make(map[K]Router[V]),
+	}
+}
+
+//router returns the Router for key, creating it on first use.
+func (t *Topic[K, V]) router(key K) Router[V] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.routers[key]
+	if !ok {
+		r = New[V](t.ctx, t.newStore())
+		t.routers[key] = r
+	}
+	return r
+}
+
+//Send submits an item under key.
+func (t *Topic[K, V]) Send(ctx context.Context, key K, item V) error { return t.router(key).Send(ctx, item) }
+
+//Tail gets any cached items under key, and all future submissions to it.
+func (t *Topic[K, V]) Tail(ctx context.Context, key K, opts SubscribeOptions, onItem func(item V)) (<-chan error, error) {
+	return t.router(key).Tail(ctx, opts, onItem)
+}
+
+//Subscribe gets all future submissions under key.
+func (t *Topic[K, V]) Subscribe(ctx context.Context, key K, opts SubscribeOptions, onItem func(item V)) (<-chan error, error) {
+	return t.router(key).Subscribe(ctx, opts, onItem)
+}
+
+//TailFilter is like Tail, but onItem is only called for items matching predicate.
+func (t *Topic[K, V]) TailFilter(ctx context.Context, key K, predicate func(item V) bool, opts SubscribeOptions, onItem func(item V)) (<-chan error, error) {
+	return t.router(key).TailFilter(ctx, predicate, opts, onItem)
+}
+
+//SubscribeFilter is like Subscribe, but onItem is only called for items matching predicate.
+func (t *Topic[K, V]) SubscribeFilter(ctx context.Context, key K, predicate func(item V) bool, opts SubscribeOptions, onItem func(item V)) (<-chan error, error) {
+	return t.router(key).SubscribeFilter(ctx, predicate, opts, onItem)
+}
+
+//SubscribeFrom replays cached items under key selected by cursor, tagged with their seq, then all future submissions.
+func (t *Topic[K, V]) SubscribeFrom(ctx context.Context, key K, cursor Cursor, opts SubscribeOptions, onItem func(seq uint64, item V)) (<-chan error, error) {
+	return t.router(key).SubscribeFrom(ctx, cursor, opts, onItem)
+}
+
+//Items gets the current list of items cached under key.
+func (t *Topic[K, V]) Items(ctx context.Context, key K) ([]V, error) { return t.router(key).Items(ctx) }
+
+//Clear clears the item cache for key.
+func (t *Topic[K, V]) Clear(ctx context.Context, key K) error { return t.router(key).Clear(ctx) }
+
+//Count counts the items cached under key.
+func (t *Topic[K, V]) Count(ctx context.Context, key K) (int, error) { return t.router(key).Count(ctx) }
+
+//Stats returns per-subscriber queue depth and drop counts for key's Router.
+func (t *Topic[K, V]) Stats(ctx context.Context, key K) ([]SubscriberStats, error) { return t.router(key).Stats(ctx) }
+
+//LastSeq returns the sequence number of the most recently sent item under key, or 0 if none has been sent yet.
+func (t *Topic[K, V]) LastSeq(ctx context.Context, key K) (uint64, error) { return t.router(key).LastSeq(ctx) }
@@ -0,0 +1,253 @@
+package eventrouter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestRouter(t *testing.T) Router[int] {
+	t.Helper()
+	return New[int](context.Background(), NewMemoryStore[Envelope[int]](0))
+}
+
+func TestDropOldestKeepsNewest(t *testing.T) {
+	r := newTestRouter(t)
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []int
+	errC, err := r.Subscribe(context.Background(), SubscribeOptions{QueueSize: 1, Policy: DropOldest}, func(item int) {
+		<-release // first item stalls here until the test releases it
+		mu.Lock()
+		got = append(got, item)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Send item 0 first and give the delivery goroutine time to pick it up and stall in onItem,
+	// so the remaining sends are the ones that actually contend for the single queue slot.
+	if err := r.Send(context.Background(), 0); err != nil {
+		t.Fatalf("Send(0): %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for i := 1; i < 5; i++ {
+		if err := r.Send(context.Background(), i); err != nil {
+			t.Fatalf("Send(%d): %v", i, err)
+		}
+	}
+	close(release)
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 2
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got[0] != 0 {
+		t.Fatalf("expected the first delivered item to be the stalled one (0), got %d", got[0])
+	}
+	if got[1] != 4 {
+		t.Fatalf("expected DropOldest to keep the newest item (4), got %d", got[1])
+	}
+	select {
+	case e := <-errC:
+		t.Fatalf("unexpected error on subscriber errC: %v", e)
+	default:
+	}
+}
+
+func TestDropNewestKeepsOldest(t *testing.T) {
+	r := newTestRouter(t)
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []int
+	_, err := r.Subscribe(context.Background(), SubscribeOptions{QueueSize: 1, Policy: DropNewest}, func(item int) {
+		<-release
+		mu.Lock()
+		got = append(got, item)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Send item 0 first and give the delivery goroutine time to pick it up and stall in onItem,
+	// so the remaining sends are the ones that actually contend for the single queue slot.
+	if err := r.Send(context.Background(), 0); err != nil {
+		t.Fatalf("Send(0): %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for i := 1; i < 5; i++ {
+		if err := r.Send(context.Background(), i); err != nil {
+			t.Fatalf("Send(%d): %v", i, err)
+		}
+	}
+	close(release)
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 2
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got[0] != 0 || got[1] != 1 {
+		t.Fatalf("expected DropNewest to keep the first two items [0 1], got %v", got)
+	}
+
+	stats, err := r.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Dropped == 0 {
+		t.Fatalf("expected a nonzero drop count, got %+v", stats)
+	}
+}
+
+func TestDisconnectClosesSubscriberOnOverflow(t *testing.T) {
+	r := newTestRouter(t)
+
+	release := make(chan struct{})
+	errC, err := r.Subscribe(context.Background(), SubscribeOptions{QueueSize: 1, Policy: Disconnect}, func(item int) {
+		<-release
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := r.Send(context.Background(), i); err != nil {
+			t.Fatalf("Send(%d): %v", i, err)
+		}
+	}
+	close(release)
+
+	select {
+	case got := <-errC:
+		if !errors.Is(got, ErrSlowConsumer) {
+			t.Fatalf("expected ErrSlowConsumer, got %v", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected errC to receive ErrSlowConsumer, timed out")
+	}
+}
+
+func TestDisconnectDuringCacheReplayDoesNotPanic(t *testing.T) {
+	// Regression test: a Disconnect-policy subscriber whose queue fills mid-replay of cached
+	// items used to panic with "send on closed channel" once deliver was called a second time
+	// for the same (now-disconnected) subscriber.
+	store := NewMemoryStore[Envelope[int]](0)
+	r := New[int](context.Background(), store)
+	for i := 0; i < 5; i++ {
+		if err := r.Send(context.Background(), i); err != nil {
+			t.Fatalf("Send(%d): %v", i, err)
+		}
+	}
+
+	errC, err := r.Tail(context.Background(), SubscribeOptions{QueueSize: 1, Policy: Disconnect}, func(item int) {})
+	if err != nil {
+		t.Fatalf("Tail: %v", err)
+	}
+
+	select {
+	case got := <-errC:
+		if !errors.Is(got, ErrSlowConsumer) {
+			t.Fatalf("expected ErrSlowConsumer, got %v", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected errC to receive ErrSlowConsumer, timed out")
+	}
+}
+
+func TestBlockBackpressuresOnlyItsOwnCaller(t *testing.T) {
+	// Regression test: Policy: Block used to send directly from the router goroutine, so a
+	// single stalled Block consumer wedged Send/Count/Clear/Subscribe for every other caller
+	// too. A stalled Block consumer here must only slow down Sends that target it.
+	r := newTestRouter(t)
+
+	stall := make(chan struct{})
+	defer close(stall)
+	_, err := r.Subscribe(context.Background(), SubscribeOptions{QueueSize: 1, Policy: Block}, func(item int) {
+		<-stall
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Fill the Block subscriber's queue so further deliveries to it must wait.
+	for i := 0; i < 3; i++ {
+		go r.Send(context.Background(), i)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := r.Count(ctx); err != nil {
+		t.Fatalf("Count timed out with a stalled Block subscriber: %v", err)
+	}
+}
+
+func TestBlockPreservesOrderAndBackpressuresSend(t *testing.T) {
+	r := newTestRouter(t)
+
+	var mu sync.Mutex
+	var got []int
+	_, err := r.Subscribe(context.Background(), SubscribeOptions{QueueSize: 1, Policy: Block}, func(item int) {
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		got = append(got, item)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	const n = 50
+	for i := 0; i < n; i++ {
+		if err := r.Send(context.Background(), i); err != nil {
+			t.Fatalf("Send(%d): %v", i, err)
+		}
+		// With QueueSize 1, Send(i) returns only once i is on the queue, which needs the consumer
+		// to have taken i-1 off it and so to have finished onItem(i-2).
+		mu.Lock()
+		delivered := len(got)
+		mu.Unlock()
+		if delivered < i-1 {
+			t.Fatalf("Send(%d) returned before the Block subscriber made room: only %d items delivered", i, delivered)
+		}
+	}
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == n
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("expected items delivered in order, got %v at index %d (want %d)", v, i, i)
+		}
+	}
+}
+
+func waitFor(t *testing.T, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
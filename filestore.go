@@ -0,0 +1,201 @@
+package eventrouter
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+//fileStore is an append-only log Store: every item is serialized as JSON and written to path
+//behind a 4-byte big-endian length prefix, so a process restart can rehydrate Tail subscribers
+//from disk instead of starting with an empty cache. Truncate/Clear compact the file in place,
+//since the log itself isn't a ring buffer.
+type fileStore[ItemType any] struct {
+	path  string
+	depth int //<1 means unbounded
+
+	mu    sync.Mutex
+	file  *os.File
+	items []ItemType //in-memory mirror, rehydrated from disk at open, kept in sync on every write
+	dirty bool
+}
+
+//NewFileStore opens (or creates) a file-backed append-only log at path, rehydrating any
+//previously persisted items into memory. If fsyncInterval is >0, a background goroutine
+//fsyncs the file on that interval whenever it has unsynced writes; the goroutine exits when
+//ctx is done. cacheDepth bounds what's kept in memory and served to Tail/Range/Len, same as
+//NewMemoryStore; it does not rewrite the on-disk log except via Truncate/Clear.
+func NewFileStore[ItemType any](ctx context.Context, path string, cacheDepth int, fsyncInterval time.Duration) (Store[ItemType], error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("eventrouter: open file store %q: %w", path, err)
+	}
+	fs := &fileStore[ItemType]{path: path, depth: cacheDepth, file: f}
+	if err := fs.rehydrate(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fsyncInterval > 0 {
+		go fs.fsyncLoop(ctx, fsyncInterval)
+	}
+	return fs, nil
+}
+
+func (fs *fileStore[ItemType]) rehydrate() error {
+	if _, err := fs.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("eventrouter: seek file store %q: %w", fs.path, err)
+	}
+	r := bufio.NewReader(fs.file)
+	var items []ItemType
+	for {
+		var length uint32
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("eventrouter: read record length from %q: %w", fs.path, err)
+		}
+		buf := make([]byte, length)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("eventrouter: read record from %q: %w", fs.path, err)
+		}
+		var item ItemType
+		if err := json.Unmarshal(buf, &item); err != nil {
+			return fmt.Errorf("eventrouter: decode record from %q: %w", fs.path, err)
+		}
+		items = append(items, item)
+	}
+	if fs.depth > 0 && len(items) > fs.depth {
+		items = items[len(items)-fs.depth:]
+	}
+	fs.items = items
+	if _, err := fs.file.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("eventrouter: seek file store %q: %w", fs.path, err)
+	}
+	return nil
+}
+
+func (fs *fileStore[ItemType]) fsyncLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fs.mu.Lock()
+			if fs.dirty {
+				fs.file.Sync()
+				fs.dirty = false
+			}
+			fs.mu.Unlock()
+		}
+	}
+}
+
+func (fs *fileStore[ItemType]) Append(item ItemType) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	buf, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("eventrouter: encode record: %w", err)
+	}
+	if err := binary.Write(fs.file, binary.BigEndian, uint32(len(buf))); err != nil {
+		return fmt.Errorf("eventrouter: write record length to %q: %w", fs.path, err)
+	}
+	if _, err := fs.file.Write(buf); err != nil {
+		return fmt.Errorf("eventrouter: write record to %q: %w", fs.path, err)
+	}
+	fs.dirty = true
+	fs.items = append(fs.items, item)
+	if fs.depth > 0 && len(fs.items) > fs.depth {
+		fs.items = fs.items[len(fs.items)-fs.depth:]
+	}
+	return nil
+}
+
+func (fs *fileStore[ItemType]) Range(fn func(item ItemType) bool) error {
+	fs.mu.Lock()
+	items := append([]ItemType(nil), fs.items...)
+	fs.mu.Unlock()
+	for _, item := range items {
+		if !fn(item) {
+			break
+		}
+	}
+	return nil
+}
+
+func (fs *fileStore[ItemType]) Len() int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return len(fs.items)
+}
+
+func (fs *fileStore[ItemType]) Truncate(keep int) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if keep < 0 {
+		keep = 0
+	}
+	if len(fs.items) <= keep {
+		return nil
+	}
+	return fs.rewrite(fs.items[len(fs.items)-keep:])
+}
+
+func (fs *fileStore[ItemType]) Clear() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.rewrite(nil)
+}
+
+//rewrite replaces the on-disk log with exactly items, then swaps it in for fs.file. Callers
+//must hold fs.mu.
+func (fs *fileStore[ItemType]) rewrite(items []ItemType) error {
+	tmpPath := fs.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("eventrouter: open compaction file %q: %w", tmpPath, err)
+	}
+	for _, item := range items {
+		buf, err := json.Marshal(item)
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("eventrouter: encode record during compaction: %w", err)
+		}
+		if err := binary.Write(tmp, binary.BigEndian, uint32(len(buf))); err != nil {
+			tmp.Close()
+			return fmt.Errorf("eventrouter: write record length during compaction: %w", err)
+		}
+		if _, err := tmp.Write(buf); err != nil {
+			tmp.Close()
+			return fmt.Errorf("eventrouter: write record during compaction: %w", err)
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("eventrouter: sync compaction file %q: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("eventrouter: close compaction file %q: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, fs.path); err != nil {
+		return fmt.Errorf("eventrouter: rename compaction file %q: %w", tmpPath, err)
+	}
+	f, err := os.OpenFile(fs.path, os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("eventrouter: reopen file store %q: %w", fs.path, err)
+	}
+	fs.file.Close()
+	fs.file = f
+	fs.items = items
+	fs.dirty = false
+	return nil
+}
@@ -0,0 +1,130 @@
+package eventrouter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func collect[ItemType any](t *testing.T, s Store[ItemType]) []ItemType {
+	t.Helper()
+	var items []ItemType
+	if err := s.Range(func(item ItemType) bool {
+		items = append(items, item)
+		return true
+	}); err != nil {
+		t.Fatalf("Range: %v", err)
+	}
+	return items
+}
+
+func TestFileStoreRehydratesOnReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	s, err := NewFileStore[int](context.Background(), path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := s.Append(i); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+
+	reopened, err := NewFileStore[int](context.Background(), path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore (reopen): %v", err)
+	}
+	got := collect(t, reopened)
+	want := []int{0, 1, 2}
+	if len(got) != len(want) {
+		t.Fatalf("rehydrated items = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("rehydrated items = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFileStoreRehydrateRespectsCacheDepth(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	s, err := NewFileStore[int](context.Background(), path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := s.Append(i); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+
+	reopened, err := NewFileStore[int](context.Background(), path, 2, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore (reopen): %v", err)
+	}
+	got := collect(t, reopened)
+	want := []int{3, 4}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("rehydrated items = %v, want %v", got, want)
+	}
+}
+
+func TestFileStoreTruncateCompactsOnDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	s, err := NewFileStore[int](context.Background(), path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := s.Append(i); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+
+	if err := s.Truncate(2); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	if got := collect(t, s); len(got) != 2 || got[0] != 3 || got[1] != 4 {
+		t.Fatalf("in-memory items after Truncate = %v, want [3 4]", got)
+	}
+
+	// Truncate must also compact the on-disk log, not just the in-memory mirror.
+	reopened, err := NewFileStore[int](context.Background(), path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore (reopen): %v", err)
+	}
+	if got := collect(t, reopened); len(got) != 2 || got[0] != 3 || got[1] != 4 {
+		t.Fatalf("rehydrated items after Truncate = %v, want [3 4]", got)
+	}
+}
+
+func TestFileStoreClearCompactsOnDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	s, err := NewFileStore[int](context.Background(), path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := s.Append(i); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+	if err := s.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if n := s.Len(); n != 0 {
+		t.Fatalf("Len after Clear = %d, want 0", n)
+	}
+
+	reopened, err := NewFileStore[int](context.Background(), path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewFileStore (reopen): %v", err)
+	}
+	if n := reopened.Len(); n != 0 {
+		t.Fatalf("Len after reopen following Clear = %d, want 0", n)
+	}
+}
@@ -0,0 +1,74 @@
+package eventrouter
+
+import "sync"
+
+//Store is the cache backend a Router uses to retain items for replay to Tail subscribers
+//and for Items()/Count(). Implementations must be safe for concurrent use, since the file
+//store's background fsync goroutine and the router goroutine can both touch it.
+type Store[ItemType any] interface {
+	Append(item ItemType) error          //append item, keeping it durable enough for the store's guarantees
+	Range(fn func(item ItemType) bool) error //call fn for each item in append order; stop early if fn returns false
+	Len() int                            //number of items currently retained
+	Truncate(keep int) error              //discard everything except the most recent keep items
+	Clear() error                         //discard everything
+}
+
+//memoryStore is the original in-RAM cache, now behind the Store interface.
+type memoryStore[ItemType any] struct {
+	mu    sync.Mutex
+	items []ItemType
+	depth int //<1 means unbounded
+}
+
+//NewMemoryStore returns a Store that keeps, at most, the most recent cacheDepth items in RAM.
+//Setting cacheDepth to <1 leaves it unbounded. This is the store New used before Store existed.
+func NewMemoryStore[ItemType any](cacheDepth int) Store[ItemType] {
+	return &memoryStore[ItemType]{depth: cacheDepth}
+}
+
+func (s *memoryStore[ItemType]) Append(item ItemType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = append(s.items, item)
+	if s.depth > 0 && len(s.items) > s.depth {
+		s.items = s.items[len(s.items)-s.depth:]
+	}
+	return nil
+}
+
+func (s *memoryStore[ItemType]) Range(fn func(item ItemType) bool) error {
+	s.mu.Lock()
+	items := append([]ItemType(nil), s.items...)
+	s.mu.Unlock()
+	for _, item := range items {
+		if !fn(item) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore[ItemType]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+func (s *memoryStore[ItemType]) Truncate(keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if keep < 0 {
+		keep = 0
+	}
+	if len(s.items) > keep {
+		s.items = s.items[len(s.items)-keep:]
+	}
+	return nil
+}
+
+func (s *memoryStore[ItemType]) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = s.items[:0]
+	return nil
+}
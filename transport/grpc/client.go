@@ -0,0 +1,28 @@
+package grpc
+
+import "context"
+
+//Client adapts a generated WatcherClient into a transport.Subscriber, so a Bridge can mirror a
+//remote subject into a local Router by calling Watch once and forwarding every Event it streams.
+type Client struct {
+	Watcher WatcherClient
+}
+
+//NewClient wraps an already-dialed WatcherClient.
+func NewClient(watcher WatcherClient) *Client { return &Client{Watcher: watcher} }
+
+//Subscribe calls Watch(subject) and forwards every Event's data to onMessage until the stream
+//ends or ctx is done.
+func (c *Client) Subscribe(ctx context.Context, subject string, onMessage func(data []byte)) error {
+	stream, err := c.Watcher.Watch(ctx, &WatchRequest{Subject: subject})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		onMessage(event.Data)
+	}
+}
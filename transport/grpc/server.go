@@ -0,0 +1,60 @@
+// Package grpc mirrors an eventrouter.Topic onto a gRPC server-streaming Watch RPC, analogous
+// to the watcher pattern in micro's router service: a client calls Watch(subject) once and gets
+// every subsequent item published under that subject pushed down a single long-lived stream.
+//
+//go:generate protoc --go_out=. --go-grpc_out=. eventrouter.proto
+package grpc
+
+import (
+	"context"
+
+	"github.com/whmeitzler/eventrouter"
+	"github.com/whmeitzler/eventrouter/transport"
+)
+
+//Server implements WatcherServer (generated from eventrouter.proto) by streaming each subject's
+//Topic-scoped Router straight to whichever client is watching it.
+type Server[ItemType any] struct {
+	UnimplementedWatcherServer
+
+	Topic *eventrouter.Topic[string, ItemType]
+	Codec transport.ItemCodec[ItemType]
+}
+
+//NewServer builds a Watcher gRPC server backed by topic, encoding items for the wire with codec.
+func NewServer[ItemType any](topic *eventrouter.Topic[string, ItemType], codec transport.ItemCodec[ItemType]) *Server[ItemType] {
+	return &Server[ItemType]{Topic: topic, Codec: codec}
+}
+
+//Watch streams items published under req.Subject to stream, until the client disconnects
+//or stream's context is cancelled. The subscription uses DropOldest with the default queue
+//size, so a client that falls behind loses the oldest undelivered items.
+func (s *Server[ItemType]) Watch(req *WatchRequest, stream Watcher_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	errC, err := s.Topic.Subscribe(ctx, req.Subject, eventrouter.SubscribeOptions{Policy: eventrouter.DropOldest}, func(item ItemType) {
+		data, err := s.Codec.Marshal(item)
+		if err != nil {
+			return
+		}
+		if err := stream.Send(&Event{Subject: req.Subject, Data: data}); err != nil {
+			sendErr = err
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		if sendErr != nil {
+			return sendErr
+		}
+		return ctx.Err()
+	case err := <-errC:
+		return err
+	}
+}
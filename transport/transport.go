@@ -0,0 +1,132 @@
+// Package transport bridges an eventrouter.Router onto an external pub/sub transport, so
+// multiple processes can share a logical stream without each rewriting the core router.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/whmeitzler/eventrouter"
+)
+
+//ItemCodec marshals/unmarshals ItemType to and from the bytes a transport actually moves.
+//Plug in JSON, protobuf, gob, or anything else that fits ItemType.
+type ItemCodec[ItemType any] interface {
+	Marshal(item ItemType) ([]byte, error)
+	Unmarshal(data []byte) (ItemType, error)
+}
+
+//JSONCodec is an ItemCodec backed by encoding/json.
+type JSONCodec[ItemType any] struct{}
+
+func (JSONCodec[ItemType]) Marshal(item ItemType) ([]byte, error) { return json.Marshal(item) }
+func (JSONCodec[ItemType]) Unmarshal(data []byte) (item ItemType, err error) {
+	err = json.Unmarshal(data, &item)
+	return item, err
+}
+
+//GobCodec is an ItemCodec backed by encoding/gob.
+type GobCodec[ItemType any] struct{}
+
+func (GobCodec[ItemType]) Marshal(item ItemType) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec[ItemType]) Unmarshal(data []byte) (item ItemType, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&item)
+	return item, err
+}
+
+//Publisher sends a single encoded message to a subject on the external transport.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+}
+
+//Subscriber delivers every message published to subject on the external transport to onMessage,
+//until ctx is done.
+type Subscriber interface {
+	Subscribe(ctx context.Context, subject string, onMessage func(data []byte)) error
+}
+
+//Bridge mirrors a Router[ItemType] onto an external transport: items sent into the router are
+//published to subject, and messages received from subject are sent into the router. Either
+//half can be nil to make the Bridge one-directional.
+type Bridge[ItemType any] struct {
+	Router     eventrouter.Router[ItemType]
+	Subject    string
+	Codec      ItemCodec[ItemType]
+	Publisher  Publisher
+	Subscriber Subscriber
+}
+
+//NewBridge builds a Bridge. Call Start to begin mirroring.
+func NewBridge[ItemType any](router eventrouter.Router[ItemType], subject string, codec ItemCodec[ItemType], pub Publisher, sub Subscriber) *Bridge[ItemType] {
+	return &Bridge[ItemType]{
+		Router:     router,
+		Subject:    subject,
+		Codec:      codec,
+		Publisher:  pub,
+		Subscriber: sub,
+	}
+}
+
+//Start begins mirroring until ctx is done: every item the Router already has plus everything
+//sent to it afterward is published to b.Subject, and every message b.Subject receives is sent
+//into b.Router. The outgoing side is a DropOldest Tail with the default queue size, so if
+//publishing falls behind, the oldest unpublished items are dropped. Start returns once both
+//directions are wired up; it does not block.
+func (b *Bridge[ItemType]) Start(ctx context.Context) <-chan error {
+	errC := make(chan error, 2)
+
+	if b.Publisher != nil {
+		outErrC, err := b.Router.Tail(ctx, eventrouter.SubscribeOptions{Policy: eventrouter.DropOldest}, func(item ItemType) {
+			data, err := b.Codec.Marshal(item)
+			if err != nil {
+				errC <- err
+				return
+			}
+			if err := b.Publisher.Publish(ctx, b.Subject, data); err != nil {
+				errC <- err
+			}
+		})
+		if err != nil {
+			errC <- err
+		} else {
+			go func() {
+				select {
+				case <-ctx.Done():
+				case err, ok := <-outErrC:
+					if ok && err != nil {
+						errC <- err
+					}
+				}
+			}()
+		}
+	}
+
+	if b.Subscriber != nil {
+		go func() {
+			err := b.Subscriber.Subscribe(ctx, b.Subject, func(data []byte) {
+				item, err := b.Codec.Unmarshal(data)
+				if err != nil {
+					errC <- err
+					return
+				}
+				if err := b.Router.Send(ctx, item); err != nil {
+					errC <- err
+				}
+			})
+			if err != nil {
+				errC <- err
+			}
+		}()
+	}
+
+	return errC
+}
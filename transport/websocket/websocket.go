@@ -0,0 +1,231 @@
+// Package websocket mirrors an eventrouter.Topic onto a WebSocket connection using a small
+// JSON-RPC dialect, in the style of tendermint's JSON-RPC-over-WebSocket client: a client sends
+// {"method":"subscribe","params":{"subject":"..."}} and receives a stream of {"subject","data"}
+// events, or {"method":"publish","params":{"subject":"...","data":...}} to push an item in.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/whmeitzler/eventrouter"
+	"github.com/whmeitzler/eventrouter/transport"
+)
+
+type rpcRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type subscribeParams struct {
+	Subject string `json:"subject"`
+}
+
+type publishParams struct {
+	Subject string `json:"subject"`
+	Data    []byte `json:"data"` //codec output, base64-encoded on the wire so any ItemCodec works
+}
+
+type event struct {
+	Subject string `json:"subject"`
+	Data    []byte `json:"data"` //codec output, base64-encoded on the wire so any ItemCodec works
+}
+
+var upgrader = websocket.Upgrader{}
+
+//safeConn serializes writes to a *websocket.Conn, which gorilla/websocket requires when more
+//than one goroutine (here: one per active subscription) may write to the same connection.
+type safeConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+func (c *safeConn) writeJSON(v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteJSON(v)
+}
+
+//Server upgrades incoming HTTP connections to WebSocket and serves the subscribe/publish
+//dialect described in the package doc against topic.
+type Server[ItemType any] struct {
+	Topic *eventrouter.Topic[string, ItemType]
+	Codec transport.ItemCodec[ItemType]
+}
+
+//NewServer builds a WebSocket handler backed by topic, encoding items for the wire with codec.
+func NewServer[ItemType any](topic *eventrouter.Topic[string, ItemType], codec transport.ItemCodec[ItemType]) *Server[ItemType] {
+	return &Server[ItemType]{Topic: topic, Codec: codec}
+}
+
+func (s *Server[ItemType]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer wsConn.Close()
+	conn := &safeConn{conn: wsConn}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	for {
+		var req rpcRequest
+		if err := wsConn.ReadJSON(&req); err != nil {
+			return
+		}
+		switch req.Method {
+		case "subscribe":
+			var params subscribeParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				continue
+			}
+			go s.stream(ctx, conn, params.Subject)
+		case "publish":
+			var params publishParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				continue
+			}
+			item, err := s.Codec.Unmarshal(params.Data)
+			if err != nil {
+				continue
+			}
+			s.Topic.Send(ctx, params.Subject, item)
+		}
+	}
+}
+
+func (s *Server[ItemType]) stream(ctx context.Context, conn *safeConn, subject string) {
+	s.Topic.Subscribe(ctx, subject, eventrouter.SubscribeOptions{Policy: eventrouter.DropOldest}, func(item ItemType) {
+		data, err := s.Codec.Marshal(item)
+		if err != nil {
+			return
+		}
+		conn.writeJSON(event{Subject: subject, Data: data})
+	})
+}
+
+//Client adapts a dialed WebSocket connection into a transport.Publisher and transport.Subscriber
+//speaking the same subscribe/publish dialect as Server.
+//
+//gorilla/websocket permits only one concurrent reader per connection, so a Client owns a single
+//demux loop (started lazily on the first Subscribe call) that reads every incoming event and
+//dispatches it by Subject to whichever Subscribe calls are listening for it, instead of each
+//Subscribe call running its own ReadJSON loop directly on the shared connection.
+type Client struct {
+	conn *safeConn
+
+	readOnce sync.Once
+
+	mu      sync.Mutex
+	subs    map[string]map[chan event]struct{}
+	closed  chan struct{}
+	readErr error
+}
+
+//eventQueueSize bounds how many undelivered events a single Subscribe call's channel will hold
+//before the demux loop starts dropping the oldest for that subscriber, so one slow onMessage
+//can't stall delivery to every other subject on the connection.
+const eventQueueSize = 64
+
+//Dial connects to url and returns a Client ready to Publish/Subscribe against it.
+func Dial(ctx context.Context, url string) (*Client, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		conn:   &safeConn{conn: conn},
+		subs:   make(map[string]map[chan event]struct{}),
+		closed: make(chan struct{}),
+	}, nil
+}
+
+//Publish sends data to subject over the connection.
+func (c *Client) Publish(ctx context.Context, subject string, data []byte) error {
+	params, err := json.Marshal(publishParams{Subject: subject, Data: data})
+	if err != nil {
+		return err
+	}
+	return c.conn.writeJSON(rpcRequest{Method: "publish", Params: params})
+}
+
+//Subscribe sends a subscribe request for subject, then forwards each event's data to onMessage
+//until the connection closes or ctx is done. If more than eventQueueSize events are waiting
+//for this call, the oldest are dropped (see readLoop). It's safe to call Subscribe more than once on the
+//same Client, including concurrently and for the same subject.
+func (c *Client) Subscribe(ctx context.Context, subject string, onMessage func(data []byte)) error {
+	params, err := json.Marshal(subscribeParams{Subject: subject})
+	if err != nil {
+		return err
+	}
+	if err := c.conn.writeJSON(rpcRequest{Method: "subscribe", Params: params}); err != nil {
+		return err
+	}
+	c.readOnce.Do(func() { go c.readLoop() })
+
+	ch := make(chan event, eventQueueSize)
+	c.mu.Lock()
+	if c.subs[subject] == nil {
+		c.subs[subject] = make(map[chan event]struct{})
+	}
+	c.subs[subject][ch] = struct{}{}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.subs[subject], ch)
+		c.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.closed:
+			c.mu.Lock()
+			err := c.readErr
+			c.mu.Unlock()
+			return err
+		case evt := <-ch:
+			onMessage(evt.Data)
+		}
+	}
+}
+
+//readLoop is the Client's single reader of conn, started once on the first Subscribe call. It
+//dispatches each incoming event to every Subscribe call currently listening for its Subject,
+//and, once the connection fails, closes c.closed so every blocked Subscribe call returns.
+func (c *Client) readLoop() {
+	for {
+		var evt event
+		if err := c.conn.conn.ReadJSON(&evt); err != nil {
+			c.mu.Lock()
+			c.readErr = err
+			c.mu.Unlock()
+			close(c.closed)
+			return
+		}
+		c.mu.Lock()
+		for ch := range c.subs[evt.Subject] {
+			select {
+			case ch <- evt:
+			default:
+				//Subscriber's queue is full: drop the oldest queued event for it rather than
+				//block the demux loop and stall delivery to every other subject.
+				select {
+				case <-ch:
+				default:
+				}
+				select {
+				case ch <- evt:
+				default:
+				}
+			}
+		}
+		c.mu.Unlock()
+	}
+}